@@ -1,19 +1,28 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/gorilla/websocket"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/auth"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/coordinator"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/lobby"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/ws"
 )
 
-var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true // TODO: Implement proper origin checking
-	},
-}
+var serverStartedAt = time.Now()
+
+// defaultTokenTTL is how long a minted token is valid for before a client
+// must refresh it.
+const defaultTokenTTL = 15 * time.Minute
 
 func main() {
 	port := os.Getenv("PORT")
@@ -21,14 +30,34 @@ func main() {
 		port = "8080"
 	}
 
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
+
+	lobbies := lobby.NewRegistry()
+	origins := ws.ParseOriginAllowList(os.Getenv("ALLOWED_ORIGINS"))
+	issuer := auth.NewIssuer([]byte(secret), defaultTokenTTL)
+
 	r := mux.NewRouter()
 
 	// HTTP routes
 	r.HandleFunc("/health", healthHandler).Methods("GET")
-	r.HandleFunc("/api/game/status", gameStatusHandler).Methods("GET")
+	r.HandleFunc("/api/game/status", gameStatusHandler(lobbies)).Methods("GET")
+	r.HandleFunc("/api/lobby", createLobbyHandler(lobbies)).Methods("POST")
+	r.HandleFunc("/api/lobby", listLobbiesHandler(lobbies)).Methods("GET")
+	r.HandleFunc("/api/lobby/{id}/join", joinLobbyHandler(lobbies)).Methods("POST")
+	r.HandleFunc("/api/lobby/{id}", leaveLobbyHandler(lobbies)).Methods("DELETE")
+	r.HandleFunc("/api/auth/login", loginHandler(issuer)).Methods("POST")
+	r.HandleFunc("/api/auth/refresh", refreshHandler(issuer)).Methods("POST")
 
 	// WebSocket route
-	r.HandleFunc("/ws", websocketHandler)
+	r.HandleFunc("/ws", ws.Handler(ws.Config{
+		Resolve: lobbyHubResolver(lobbies),
+		Origins: origins,
+		Auth:    issuer,
+		Wire:    wire.DefaultRegistry(),
+	}))
 
 	log.Printf("Game server starting on port %s", port)
 	log.Fatal(http.ListenAndServe(":"+port, r))
@@ -39,33 +68,188 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("OK"))
 }
 
-func gameStatusHandler(w http.ResponseWriter, r *http.Request) {
-	// TODO: Implement game status endpoint
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"status":"running"}`))
+func gameStatusHandler(lobbies *lobby.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		players := 0
+		for _, l := range lobbies.List() {
+			players += l.PlayerCount()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":         "running",
+			"lobbies":        len(lobbies.List()),
+			"players":        players,
+			"tick_rate_hz":   coordinator.TickRate,
+			"uptime_seconds": time.Since(serverStartedAt).Seconds(),
+		})
+	}
+}
+
+type createLobbyRequest struct {
+	Name string `json:"name"`
+}
+
+type lobbyResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Players int    `json:"players"`
+}
+
+func toLobbyResponse(l *lobby.Lobby) lobbyResponse {
+	return lobbyResponse{ID: l.ID, Name: l.Name, Players: l.PlayerCount()}
 }
 
-func websocketHandler(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("WebSocket upgrade error: %v", err)
-		return
+func createLobbyHandler(lobbies *lobby.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createLobbyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+
+		l := lobbies.Create(req.Name)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(toLobbyResponse(l))
+	}
+}
+
+func listLobbiesHandler(lobbies *lobby.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list := lobbies.List()
+		out := make([]lobbyResponse, len(list))
+		for i, l := range list {
+			out[i] = toLobbyResponse(l)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(out)
 	}
-	defer conn.Close()
+}
+
+func joinLobbyHandler(lobbies *lobby.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
 
-	// TODO: Implement WebSocket game logic
-	for {
-		messageType, message, err := conn.ReadMessage()
+		l, err := lobbies.Get(id)
 		if err != nil {
-			log.Printf("WebSocket read error: %v", err)
-			break
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(toLobbyResponse(l))
+	}
+}
+
+// leaveLobbyHandler disbands the named lobby and stops its hub. Its ID is
+// not reused; later /ws?lobby={id} connections for it are rejected because
+// the ID no longer resolves.
+func leaveLobbyHandler(lobbies *lobby.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+
+		if err := lobbies.Remove(id); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
 		}
 
-		// Echo message back (placeholder)
-		if err := conn.WriteMessage(messageType, message); err != nil {
-			log.Printf("WebSocket write error: %v", err)
-			break
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type loginRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type tokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// loginHandler mints a token for the given user_id. There is no password or
+// account store yet, so this simply establishes identity for whatever
+// caller can reach the API; it's the seam a real credential check will
+// plug into.
+func loginHandler(issuer *auth.Issuer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req loginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.UserID == "" {
+			http.Error(w, "user_id is required", http.StatusBadRequest)
+			return
+		}
+
+		token, expiresAt, err := issuer.Mint(req.UserID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(tokenResponse{Token: token, ExpiresAt: expiresAt})
+	}
+}
+
+type refreshRequest struct {
+	Token string `json:"token"`
+}
+
+// refreshHandler exchanges a still-valid token for a new one and revokes
+// the old one, so a client can stay authenticated past defaultTokenTTL
+// without the server ever trusting a single token indefinitely.
+func refreshHandler(issuer *auth.Issuer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req refreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		claims, err := issuer.Parse(req.Token)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid token: %v", err), http.StatusUnauthorized)
+			return
+		}
+
+		token, expiresAt, err := issuer.Mint(claims.UserID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		issuer.Revoke(claims.ID, claims.ExpiresAt.Time)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(tokenResponse{Token: token, ExpiresAt: expiresAt})
+	}
+}
+
+// lobbyHubResolver routes a /ws?lobby={id} request to that lobby's hub.
+func lobbyHubResolver(lobbies *lobby.Registry) ws.HubResolver {
+	return func(r *http.Request) (*coordinator.Hub, error) {
+		id := r.URL.Query().Get("lobby")
+		if id == "" {
+			return nil, errors.New("lobby query parameter is required")
+		}
+
+		l, err := lobbies.Get(id)
+		if err != nil {
+			return nil, err
 		}
+		return l.Hub, nil
 	}
 }
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/auth"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/lobby"
+)
+
+func TestCreateLobbyHandler(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+	h := createLobbyHandler(lobbies)
+
+	body, _ := json.Marshal(createLobbyRequest{Name: "room-a"})
+	req := httptest.NewRequest(http.MethodPost, "/api/lobby", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+
+	var resp lobbyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Name != "room-a" {
+		t.Fatalf("expected name room-a, got %q", resp.Name)
+	}
+}
+
+func TestCreateLobbyHandlerRejectsMissingName(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+	h := createLobbyHandler(lobbies)
+
+	body, _ := json.Marshal(createLobbyRequest{})
+	req := httptest.NewRequest(http.MethodPost, "/api/lobby", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for empty name, got %d", rec.Code)
+	}
+}
+
+func TestListLobbiesHandler(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+	lobbies.Create("room-a")
+	lobbies.Create("room-b")
+
+	h := listLobbiesHandler(lobbies)
+	req := httptest.NewRequest(http.MethodGet, "/api/lobby", nil)
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var resp []lobbyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected 2 lobbies, got %d", len(resp))
+	}
+}
+
+func TestJoinLobbyHandler(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+	l := lobbies.Create("room-a")
+
+	h := joinLobbyHandler(lobbies)
+	req := httptest.NewRequest(http.MethodPost, "/api/lobby/"+l.ID+"/join", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": l.ID})
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestJoinLobbyHandlerRejectsUnknownID(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+
+	h := joinLobbyHandler(lobbies)
+	req := httptest.NewRequest(http.MethodPost, "/api/lobby/does-not-exist/join", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "does-not-exist"})
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown lobby, got %d", rec.Code)
+	}
+}
+
+func TestLeaveLobbyHandler(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+	l := lobbies.Create("room-a")
+
+	h := leaveLobbyHandler(lobbies)
+	req := httptest.NewRequest(http.MethodDelete, "/api/lobby/"+l.ID, nil)
+	req = mux.SetURLVars(req, map[string]string{"id": l.ID})
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+
+	if _, err := lobbies.Get(l.ID); err != lobby.ErrNotFound {
+		t.Fatalf("expected lobby to be removed, got %v", err)
+	}
+}
+
+func TestLeaveLobbyHandlerRejectsUnknownID(t *testing.T) {
+	lobbies := lobby.NewRegistry()
+
+	h := leaveLobbyHandler(lobbies)
+	req := httptest.NewRequest(http.MethodDelete, "/api/lobby/does-not-exist", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "does-not-exist"})
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown lobby, got %d", rec.Code)
+	}
+}
+
+func TestLoginHandler(t *testing.T) {
+	issuer := auth.NewIssuer([]byte("test-secret"), time.Minute)
+	h := loginHandler(issuer)
+
+	body, _ := json.Marshal(loginRequest{UserID: "player-1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Token == "" {
+		t.Fatal("expected a non-empty token")
+	}
+
+	claims, err := issuer.Parse(resp.Token)
+	if err != nil {
+		t.Fatalf("expected minted token to parse, got error: %v", err)
+	}
+	if claims.UserID != "player-1" {
+		t.Fatalf("expected user_id player-1, got %q", claims.UserID)
+	}
+}
+
+func TestLoginHandlerRejectsMissingUserID(t *testing.T) {
+	issuer := auth.NewIssuer([]byte("test-secret"), time.Minute)
+	h := loginHandler(issuer)
+
+	body, _ := json.Marshal(loginRequest{})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing user_id, got %d", rec.Code)
+	}
+}
+
+func TestRefreshHandler(t *testing.T) {
+	issuer := auth.NewIssuer([]byte("test-secret"), time.Minute)
+	token, _, err := issuer.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	h := refreshHandler(issuer)
+	body, _ := json.Marshal(refreshRequest{Token: token})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var resp tokenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Token == "" || resp.Token == token {
+		t.Fatalf("expected a new, different token, got %q", resp.Token)
+	}
+
+	if _, err := issuer.Parse(token); err != auth.ErrRevoked {
+		t.Fatalf("expected old token to be revoked after refresh, got %v", err)
+	}
+}
+
+func TestRefreshHandlerRejectsInvalidToken(t *testing.T) {
+	issuer := auth.NewIssuer([]byte("test-secret"), time.Minute)
+	h := refreshHandler(issuer)
+
+	body, _ := json.Marshal(refreshRequest{Token: "not-a-real-token"})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for invalid token, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,128 @@
+// Package auth mints and validates the JWTs that gate access to game
+// sessions. Tokens are short-lived and re-checked periodically for the
+// lifetime of a WebSocket connection so a revoked or expired token tears
+// the connection down rather than being trusted for its whole duration.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// ErrRevoked is returned by Parse for a token whose jti has been revoked,
+// even if the token's own expiry has not yet passed.
+var ErrRevoked = errors.New("auth: token has been revoked")
+
+// Claims is the JWT payload minted for an authenticated user.
+type Claims struct {
+	UserID string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// Issuer mints and validates HMAC-signed JWTs for a single secret key. The
+// zero value is not usable; construct one with NewIssuer.
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+
+	mu sync.Mutex
+	// revoked maps a revoked token's jti to its own expiry, so entries can be
+	// swept once that expiry passes rather than accumulating forever.
+	revoked map[string]time.Time
+}
+
+// NewIssuer constructs an Issuer that signs tokens with secret and issues
+// them with a lifetime of ttl.
+func NewIssuer(secret []byte, ttl time.Duration) *Issuer {
+	return &Issuer{
+		secret:  secret,
+		ttl:     ttl,
+		revoked: make(map[string]time.Time),
+	}
+}
+
+// Mint issues a new signed token for userID.
+func (i *Issuer) Mint(userID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("auth: sign token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse validates tokenString's signature, expiry, and revocation status,
+// returning its claims if it is currently valid.
+func (i *Issuer) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return i.secret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("auth: parse token: %w", err)
+	}
+
+	if i.isRevoked(claims.ID) {
+		return nil, ErrRevoked
+	}
+	return claims, nil
+}
+
+// Revoke marks the token identified by jti as no longer valid until
+// expiresAt, even if the token would otherwise still pass signature and
+// expiry checks. expiresAt should be the token's own expiry, so the entry
+// can be swept once Parse would reject the token on expiry grounds anyway.
+func (i *Issuer) Revoke(jti string, expiresAt time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.sweepExpiredLocked()
+	i.revoked[jti] = expiresAt
+}
+
+func (i *Issuer) isRevoked(jti string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	expiresAt, ok := i.revoked[jti]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiresAt) {
+		delete(i.revoked, jti)
+		return false
+	}
+	return true
+}
+
+// sweepExpiredLocked discards revocation entries whose own expiry has
+// already passed. Called opportunistically from Revoke so a long-running
+// server's revocation set doesn't grow without bound. Callers must hold
+// i.mu.
+func (i *Issuer) sweepExpiredLocked() {
+	now := time.Now()
+	for jti, expiresAt := range i.revoked {
+		if now.After(expiresAt) {
+			delete(i.revoked, jti)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssuerMintAndParse(t *testing.T) {
+	i := NewIssuer([]byte("test-secret"), time.Minute)
+
+	token, expiresAt, err := i.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+	if expiresAt.Before(time.Now()) {
+		t.Fatalf("expected expiry in the future, got %v", expiresAt)
+	}
+
+	claims, err := i.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if claims.UserID != "player-1" {
+		t.Fatalf("expected user_id player-1, got %q", claims.UserID)
+	}
+}
+
+func TestIssuerParseRejectsExpired(t *testing.T) {
+	i := NewIssuer([]byte("test-secret"), -time.Minute)
+
+	token, _, err := i.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	if _, err := i.Parse(token); err == nil {
+		t.Fatal("expected Parse to reject an expired token")
+	}
+}
+
+func TestIssuerParseRejectsWrongSecret(t *testing.T) {
+	i := NewIssuer([]byte("test-secret"), time.Minute)
+	other := NewIssuer([]byte("other-secret"), time.Minute)
+
+	token, _, err := i.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	if _, err := other.Parse(token); err == nil {
+		t.Fatal("expected Parse to reject a token signed with a different secret")
+	}
+}
+
+func TestIssuerRevoke(t *testing.T) {
+	i := NewIssuer([]byte("test-secret"), time.Minute)
+
+	token, _, err := i.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	claims, err := i.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	i.Revoke(claims.ID, claims.ExpiresAt.Time)
+
+	if _, err := i.Parse(token); err != ErrRevoked {
+		t.Fatalf("expected ErrRevoked after revocation, got %v", err)
+	}
+}
+
+func TestIssuerRevokeEvictsAfterOwnExpiry(t *testing.T) {
+	i := NewIssuer([]byte("test-secret"), time.Minute)
+
+	token, _, err := i.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	claims, err := i.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	i.Revoke(claims.ID, time.Now().Add(-time.Second))
+
+	if len(i.revoked) != 1 {
+		t.Fatalf("expected the stale revocation to still be recorded before a sweep, got %d entries", len(i.revoked))
+	}
+
+	if i.isRevoked(claims.ID) {
+		t.Fatal("expected a revocation past its own expiry to be treated as no longer revoked")
+	}
+	if len(i.revoked) != 0 {
+		t.Fatalf("expected isRevoked to evict the stale entry, got %d entries remaining", len(i.revoked))
+	}
+}
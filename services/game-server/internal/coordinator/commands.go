@@ -0,0 +1,44 @@
+package coordinator
+
+import "encoding/json"
+
+// CommandType enumerates the kinds of messages a client may send to the hub.
+type CommandType string
+
+const (
+	// CommandJoin (re)creates the sending client's player in the simulation.
+	// Registration with the hub happens separately, via Hub.Register.
+	CommandJoin CommandType = "join"
+	// CommandLeave removes the sending client's player from the simulation.
+	// The client stays registered with the hub and still receives broadcasts.
+	CommandLeave CommandType = "leave"
+	// CommandMove carries a movement intent for the sending client.
+	CommandMove CommandType = "move"
+	// CommandPoll requests the current session state without mutating it.
+	CommandPoll CommandType = "poll"
+	// CommandTick identifies a server-pushed GameState snapshot. Clients
+	// never send it; it only ever appears on SessionResponse.
+	CommandTick CommandType = "tick"
+)
+
+// SessionCommand is the typed envelope clients send over the WebSocket.
+// Payload is left raw so each command can define its own shape without the
+// hub needing to know about every client-facing struct up front.
+type SessionCommand struct {
+	Command CommandType     `json:"command"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// SessionResponse is the typed envelope the hub sends back to clients,
+// either in direct reply to a SessionCommand or as part of a broadcast.
+type SessionResponse struct {
+	Command CommandType `json:"command"`
+	OK      bool        `json:"ok"`
+	Error   string      `json:"error,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// MovePayload is the expected Payload shape for CommandMove.
+type MovePayload struct {
+	Direction string `json:"direction"`
+}
@@ -0,0 +1,221 @@
+// Package coordinator owns authoritative game session state and dispatches
+// typed client commands against it. It has no knowledge of WebSockets or
+// HTTP; transport lifecycle lives in internal/ws.
+package coordinator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+)
+
+// TickRate is the fixed rate at which the hub advances its simulation and
+// broadcasts GameState snapshots.
+const TickRate = 20 // Hz
+
+var tickInterval = time.Second / TickRate
+
+// Client is anything the hub can register, dispatch commands for, and send
+// responses to. internal/ws provides the concrete WebSocket-backed
+// implementation; tests can supply a fake.
+type Client interface {
+	// ID uniquely identifies this client for the lifetime of the session.
+	ID() string
+	// Send delivers a response to the client's outbound queue, encoding it
+	// with the client's own codec. It must not block the hub's run loop, so
+	// implementations should buffer internally.
+	Send(SessionResponse)
+	// Codec reports the wire format this client negotiated, so broadcasts
+	// can be encoded once per codec rather than once per client.
+	Codec() wire.Codec
+	// SendRaw delivers an already-encoded frame to the client's outbound
+	// queue. Used by broadcasts that have encoded resp once for this
+	// client's codec. Must not block the hub's run loop.
+	SendRaw(data []byte, binary bool)
+}
+
+type clientCommand struct {
+	client  Client
+	command SessionCommand
+}
+
+// Hub is the single goroutine that owns session state and serializes all
+// access to it through its run loop. Create one with NewHub and start it
+// with Run in its own goroutine.
+type Hub struct {
+	clients     map[Client]bool
+	players     map[Client]*playerRuntime
+	register    chan Client
+	unregister  chan Client
+	commands    chan clientCommand
+	playerCount chan chan int
+	done        chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewHub constructs a Hub ready to be started with Run.
+func NewHub() *Hub {
+	return &Hub{
+		clients:     make(map[Client]bool),
+		players:     make(map[Client]*playerRuntime),
+		register:    make(chan Client),
+		unregister:  make(chan Client),
+		commands:    make(chan clientCommand),
+		playerCount: make(chan chan int),
+		done:        make(chan struct{}),
+	}
+}
+
+// Register adds a client to the hub. Safe to call from any goroutine.
+func (h *Hub) Register(c Client) {
+	h.register <- c
+}
+
+// Unregister removes a client from the hub. Safe to call from any goroutine.
+func (h *Hub) Unregister(c Client) {
+	h.unregister <- c
+}
+
+// Dispatch hands a client's command to the hub's run loop for processing.
+// Safe to call from any goroutine.
+func (h *Hub) Dispatch(c Client, cmd SessionCommand) {
+	h.commands <- clientCommand{client: c, command: cmd}
+}
+
+// PlayerCount returns the number of currently registered clients. The count
+// is read inside the run loop, so it never races with register/unregister
+// mutating the underlying map from Run's goroutine.
+func (h *Hub) PlayerCount() int {
+	reply := make(chan int, 1)
+	h.playerCount <- reply
+	return <-reply
+}
+
+// Stop terminates the hub's run loop. Safe to call from any goroutine, and
+// safe to call more than once. Callers must not still have clients
+// registered with the hub when they call Stop, since Register/Unregister/
+// Dispatch block forever once Run has returned.
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() { close(h.done) })
+}
+
+// Run executes the hub's single-threaded event loop, including the fixed-
+// rate simulation tick, until Stop is called. Callers should invoke it with
+// `go hub.Run()`.
+func (h *Hub) Run() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case c := <-h.register:
+			h.clients[c] = true
+			h.players[c] = &playerRuntime{}
+			log.Printf("coordinator: client %s joined (%d total)", c.ID(), len(h.clients))
+
+		case c := <-h.unregister:
+			if _, ok := h.clients[c]; ok {
+				delete(h.clients, c)
+				delete(h.players, c)
+				log.Printf("coordinator: client %s left (%d total)", c.ID(), len(h.clients))
+			}
+
+		case cc := <-h.commands:
+			h.handle(cc.client, cc.command)
+
+		case reply := <-h.playerCount:
+			reply <- len(h.clients)
+
+		case <-ticker.C:
+			h.tick()
+
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// broadcastToClients encodes resp once per distinct codec in use and writes
+// the resulting bytes to every client sharing that codec, instead of
+// re-marshaling resp once per connection.
+func (h *Hub) broadcastToClients(resp SessionResponse) {
+	encoded := make(map[wire.Codec][]byte, 1)
+	for c := range h.clients {
+		codec := c.Codec()
+		data, ok := encoded[codec]
+		if !ok {
+			var err error
+			data, err = codec.Marshal(resp)
+			if err != nil {
+				log.Printf("coordinator: failed to encode broadcast with codec %s: %v", codec.Name(), err)
+				continue
+			}
+			encoded[codec] = data
+		}
+		c.SendRaw(data, codec.Binary())
+	}
+}
+
+// tick advances every player by their current movement intent and
+// broadcasts the resulting GameState snapshot. It only ever runs on the
+// hub's own goroutine, so it can mutate playerRuntime state directly.
+func (h *Hub) tick() {
+	if len(h.players) == 0 {
+		return
+	}
+
+	snapshot := GameState{Players: make(map[string]PlayerState, len(h.players))}
+	for c, p := range h.players {
+		p.applyIntent()
+		snapshot.Players[c.ID()] = p.state
+	}
+
+	h.broadcastToClients(SessionResponse{Command: CommandTick, OK: true, Data: snapshot})
+}
+
+func (h *Hub) handle(c Client, cmd SessionCommand) {
+	switch cmd.Command {
+	case CommandJoin:
+		// The client is already registered with the hub as of the
+		// WebSocket connecting (see Register); Join is an application-level
+		// readiness acknowledgement rather than a second registration path.
+		// It does, however, need to (re)create the player's simulation state,
+		// since CommandLeave strips it without tearing down the connection.
+		if _, ok := h.players[c]; !ok {
+			h.players[c] = &playerRuntime{}
+		}
+		c.Send(SessionResponse{Command: CommandJoin, OK: true})
+
+	case CommandLeave:
+		delete(h.players, c)
+		c.Send(SessionResponse{Command: CommandLeave, OK: true})
+
+	case CommandMove:
+		var move MovePayload
+		if err := json.Unmarshal(cmd.Payload, &move); err != nil {
+			c.Send(SessionResponse{Command: CommandMove, OK: false, Error: fmt.Sprintf("invalid move payload: %v", err)})
+			return
+		}
+		if !isValidDirection(move.Direction) {
+			c.Send(SessionResponse{Command: CommandMove, OK: false, Error: fmt.Sprintf("unknown direction %q", move.Direction)})
+			return
+		}
+		p, ok := h.players[c]
+		if !ok {
+			c.Send(SessionResponse{Command: CommandMove, OK: false, Error: "client has not joined"})
+			return
+		}
+		p.intent = move.Direction
+		c.Send(SessionResponse{Command: CommandMove, OK: true})
+
+	case CommandPoll:
+		c.Send(SessionResponse{Command: CommandPoll, OK: true, Data: map[string]int{"players": len(h.clients)}})
+
+	default:
+		c.Send(SessionResponse{Command: cmd.Command, OK: false, Error: "unknown command"})
+	}
+}
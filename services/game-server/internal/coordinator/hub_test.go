@@ -0,0 +1,213 @@
+package coordinator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+)
+
+type fakeClient struct {
+	id   string
+	recv chan SessionResponse
+}
+
+func newFakeClient(id string) *fakeClient {
+	return &fakeClient{id: id, recv: make(chan SessionResponse, 16)}
+}
+
+func (f *fakeClient) ID() string { return f.id }
+
+func (f *fakeClient) Codec() wire.Codec { return wire.JSON }
+
+func (f *fakeClient) Send(resp SessionResponse) { f.recv <- resp }
+
+// SendRaw decodes the hub's already-encoded broadcast frame back into a
+// SessionResponse so tests can assert on it the same way they do for direct
+// Send calls. Tick broadcasts carry a GameState in Data; round-tripping
+// through JSON loses that concrete type unless decoded explicitly here.
+func (f *fakeClient) SendRaw(data []byte, binary bool) {
+	var frame struct {
+		Command CommandType     `json:"command"`
+		OK      bool            `json:"ok"`
+		Error   string          `json:"error,omitempty"`
+		Data    json.RawMessage `json:"data,omitempty"`
+	}
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return
+	}
+
+	resp := SessionResponse{Command: frame.Command, OK: frame.OK, Error: frame.Error}
+	if frame.Command == CommandTick {
+		var snapshot GameState
+		if err := json.Unmarshal(frame.Data, &snapshot); err == nil {
+			resp.Data = snapshot
+		}
+	}
+
+	select {
+	case f.recv <- resp:
+	default:
+	}
+}
+
+func (f *fakeClient) await(t *testing.T) SessionResponse {
+	t.Helper()
+	select {
+	case resp := <-f.recv:
+		return resp
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for response to client %s", f.id)
+		return SessionResponse{}
+	}
+}
+
+func TestHubJoinAndPoll(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Dispatch(c, SessionCommand{Command: CommandJoin})
+	if resp := c.await(t); !resp.OK {
+		t.Fatalf("expected join to succeed, got %+v", resp)
+	}
+
+	h.Dispatch(c, SessionCommand{Command: CommandPoll})
+	if resp := c.await(t); !resp.OK || resp.Command != CommandPoll {
+		t.Fatalf("expected poll response, got %+v", resp)
+	}
+}
+
+func TestHubMoveInvalidPayload(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Dispatch(c, SessionCommand{Command: CommandMove, Payload: json.RawMessage(`not-json`)})
+
+	resp := c.await(t)
+	if resp.OK {
+		t.Fatalf("expected move with invalid payload to fail, got %+v", resp)
+	}
+}
+
+func TestHubUnknownCommand(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Dispatch(c, SessionCommand{Command: "bogus"})
+
+	resp := c.await(t)
+	if resp.OK {
+		t.Fatalf("expected unknown command to fail, got %+v", resp)
+	}
+}
+
+func TestHubMoveUnknownDirection(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Register(c)
+	h.Dispatch(c, SessionCommand{Command: CommandMove, Payload: json.RawMessage(`{"direction":"sideways"}`)})
+
+	resp := c.await(t)
+	if resp.OK {
+		t.Fatalf("expected unknown direction to fail, got %+v", resp)
+	}
+}
+
+func TestHubMoveAfterRejoin(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Register(c)
+
+	h.Dispatch(c, SessionCommand{Command: CommandLeave})
+	if resp := c.await(t); !resp.OK {
+		t.Fatalf("expected leave to succeed, got %+v", resp)
+	}
+
+	h.Dispatch(c, SessionCommand{Command: CommandJoin})
+	if resp := c.await(t); !resp.OK {
+		t.Fatalf("expected rejoin to succeed, got %+v", resp)
+	}
+
+	h.Dispatch(c, SessionCommand{Command: CommandMove, Payload: json.RawMessage(`{"direction":"right"}`)})
+	if resp := c.await(t); !resp.OK {
+		t.Fatalf("expected move after rejoin to succeed, got %+v", resp)
+	}
+}
+
+func TestHubStopEndsRunLoop(t *testing.T) {
+	h := NewHub()
+	stopped := make(chan struct{})
+	go func() {
+		h.Run()
+		close(stopped)
+	}()
+
+	h.Stop()
+	h.Stop() // must not panic on a second call
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected Run to return after Stop")
+	}
+}
+
+func TestHubPlayerCountConcurrentWithRegister(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			c := newFakeClient("churn")
+			h.Register(c)
+			h.Unregister(c)
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		h.PlayerCount()
+	}
+	<-done
+}
+
+func TestHubTickAppliesMovement(t *testing.T) {
+	h := NewHub()
+	go h.Run()
+
+	c := newFakeClient("c1")
+	h.Register(c)
+	h.Dispatch(c, SessionCommand{Command: CommandMove, Payload: json.RawMessage(`{"direction":"right"}`)})
+
+	if resp := c.await(t); !resp.OK || resp.Command != CommandMove {
+		t.Fatalf("expected move ack, got %+v", resp)
+	}
+
+	for i := 0; i < 10; i++ {
+		resp := c.await(t)
+		if resp.Command != CommandTick {
+			continue
+		}
+		snapshot, ok := resp.Data.(GameState)
+		if !ok {
+			t.Fatalf("expected tick Data to be a GameState, got %T", resp.Data)
+		}
+		player, ok := snapshot.Players[c.ID()]
+		if !ok {
+			t.Fatalf("expected snapshot to contain player %s", c.ID())
+		}
+		if player.X > 0 && player.Facing == "right" {
+			return
+		}
+	}
+	t.Fatal("player position never advanced after a right move intent")
+}
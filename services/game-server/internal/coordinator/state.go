@@ -0,0 +1,53 @@
+package coordinator
+
+// PlayerState is the authoritative, server-owned state for a single player.
+type PlayerState struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Facing string  `json:"facing"`
+}
+
+// GameState is the snapshot broadcast to every client once per tick.
+type GameState struct {
+	Players map[string]PlayerState `json:"players"`
+}
+
+// moveStep is the distance a single movement intent advances a player in one
+// tick. It intentionally has no relation to TickRate; speed tuning is a
+// product decision, not a networking one.
+const moveStep = 1.0
+
+// playerRuntime tracks a connected player's authoritative position plus the
+// last movement intent received from their client. Intents persist across
+// ticks until changed or cleared, so a held direction keeps moving the
+// player every tick rather than requiring one message per step.
+type playerRuntime struct {
+	state  PlayerState
+	intent string
+}
+
+func (p *playerRuntime) applyIntent() {
+	switch p.intent {
+	case "up":
+		p.state.Y -= moveStep
+		p.state.Facing = "up"
+	case "down":
+		p.state.Y += moveStep
+		p.state.Facing = "down"
+	case "left":
+		p.state.X -= moveStep
+		p.state.Facing = "left"
+	case "right":
+		p.state.X += moveStep
+		p.state.Facing = "right"
+	}
+}
+
+func isValidDirection(dir string) bool {
+	switch dir {
+	case "up", "down", "left", "right":
+		return true
+	default:
+		return false
+	}
+}
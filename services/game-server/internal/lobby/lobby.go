@@ -0,0 +1,36 @@
+// Package lobby implements room-based matchmaking: players create and
+// discover named lobbies before a game's WebSocket session begins. Each
+// lobby owns its own coordinator.Hub so gameplay state never leaks between
+// rooms.
+package lobby
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/coordinator"
+)
+
+// Lobby is a single named room that clients can join before a game starts.
+type Lobby struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	Hub       *coordinator.Hub
+}
+
+// PlayerCount reports how many clients are currently registered with the
+// lobby's hub.
+func (l *Lobby) PlayerCount() int {
+	return l.Hub.PlayerCount()
+}
+
+func newLobby(name string) *Lobby {
+	return &Lobby{
+		ID:        uuid.NewString(),
+		Name:      name,
+		CreatedAt: time.Now(),
+		Hub:       coordinator.NewHub(),
+	}
+}
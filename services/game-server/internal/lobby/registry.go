@@ -0,0 +1,78 @@
+package lobby
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrNotFound is returned when a lobby ID has no matching lobby.
+var ErrNotFound = errors.New("lobby: not found")
+
+// Registry tracks all active lobbies and is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	lobbies map[string]*Lobby
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{lobbies: make(map[string]*Lobby)}
+}
+
+// Create makes a new lobby with the given name, starts its hub, and
+// registers it. The caller owns the returned Lobby for as long as the
+// registry holds it; call Remove when the lobby is no longer needed.
+func (r *Registry) Create(name string) *Lobby {
+	l := newLobby(name)
+	go l.Hub.Run()
+
+	r.mu.Lock()
+	r.lobbies[l.ID] = l
+	r.mu.Unlock()
+
+	return l
+}
+
+// Get returns the lobby with the given ID, or ErrNotFound.
+func (r *Registry) Get(id string) (*Lobby, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	l, ok := r.lobbies[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return l, nil
+}
+
+// Remove disbands the lobby with the given ID, stopping its hub, or returns
+// ErrNotFound if no such lobby exists.
+func (r *Registry) Remove(id string) error {
+	r.mu.Lock()
+	l, ok := r.lobbies[id]
+	if !ok {
+		r.mu.Unlock()
+		return ErrNotFound
+	}
+	delete(r.lobbies, id)
+	r.mu.Unlock()
+
+	l.Hub.Stop()
+	return nil
+}
+
+// List returns all lobbies ordered by creation time, oldest first.
+func (r *Registry) List() []*Lobby {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*Lobby, 0, len(r.lobbies))
+	for _, l := range r.lobbies {
+		out = append(out, l)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out
+}
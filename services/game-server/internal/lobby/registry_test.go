@@ -0,0 +1,56 @@
+package lobby
+
+import "testing"
+
+func TestRegistryCreateGetList(t *testing.T) {
+	r := NewRegistry()
+
+	a := r.Create("room-a")
+	b := r.Create("room-b")
+
+	got, err := r.Get(a.ID)
+	if err != nil {
+		t.Fatalf("Get(%q) returned error: %v", a.ID, err)
+	}
+	if got != a {
+		t.Fatalf("Get(%q) returned a different lobby", a.ID)
+	}
+
+	list := r.List()
+	if len(list) != 2 {
+		t.Fatalf("expected 2 lobbies, got %d", len(list))
+	}
+	if list[0].ID != a.ID || list[1].ID != b.ID {
+		t.Fatalf("expected lobbies in creation order, got %+v", list)
+	}
+}
+
+func TestRegistryGetMissing(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.Get("does-not-exist"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRegistryRemove(t *testing.T) {
+	r := NewRegistry()
+
+	a := r.Create("room-a")
+
+	if err := r.Remove(a.ID); err != nil {
+		t.Fatalf("Remove(%q) returned error: %v", a.ID, err)
+	}
+
+	if _, err := r.Get(a.ID); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound after Remove, got %v", err)
+	}
+}
+
+func TestRegistryRemoveMissing(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Remove("does-not-exist"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
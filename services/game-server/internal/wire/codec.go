@@ -0,0 +1,17 @@
+// Package wire defines the pluggable frame encodings a WebSocket connection
+// can negotiate, so high-frequency tick updates can use a compact binary
+// format while simpler clients keep using plain JSON.
+package wire
+
+// Codec marshals and unmarshals values for a single wire format, identified
+// by the Sec-WebSocket-Protocol token clients negotiate it with.
+type Codec interface {
+	// Name is the Sec-WebSocket-Protocol identifier for this codec, e.g.
+	// "arcadium.json.v1".
+	Name() string
+	// Binary reports whether frames using this codec must be sent as
+	// websocket.BinaryMessage rather than websocket.TextMessage.
+	Binary() bool
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
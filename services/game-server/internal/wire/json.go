@@ -0,0 +1,13 @@
+package wire
+
+import "encoding/json"
+
+type jsonCodec struct{}
+
+// JSON is the default, human-readable text codec.
+var JSON Codec = jsonCodec{}
+
+func (jsonCodec) Name() string                               { return "arcadium.json.v1" }
+func (jsonCodec) Binary() bool                               { return false }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
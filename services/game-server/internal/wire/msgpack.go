@@ -0,0 +1,14 @@
+package wire
+
+import "github.com/vmihailenco/msgpack/v5"
+
+type msgpackCodec struct{}
+
+// MsgPack is a compact binary codec, preferred for high-frequency tick
+// traffic over JSON's text overhead.
+var MsgPack Codec = msgpackCodec{}
+
+func (msgpackCodec) Name() string                               { return "arcadium.msgpack.v1" }
+func (msgpackCodec) Binary() bool                               { return true }
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error)      { return msgpack.Marshal(v) }
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error { return msgpack.Unmarshal(data, v) }
@@ -0,0 +1,39 @@
+package wire
+
+// Registry maps negotiated Sec-WebSocket-Protocol tokens to their Codec. A
+// protobuf codec (arcadium.proto.v1) is a planned addition once game state
+// has a stable .proto schema; for now only JSON and MessagePack are wired
+// up.
+type Registry struct {
+	codecs   map[string]Codec
+	fallback Codec
+}
+
+// NewRegistry builds a Registry whose default codec (used when a client
+// negotiates no known protocol) is def, plus any additional codecs.
+func NewRegistry(def Codec, extra ...Codec) *Registry {
+	r := &Registry{codecs: make(map[string]Codec, len(extra)+1), fallback: def}
+	r.codecs[def.Name()] = def
+	for _, c := range extra {
+		r.codecs[c.Name()] = c
+	}
+	return r
+}
+
+// DefaultRegistry returns a Registry with JSON as the default codec and
+// MessagePack available for clients that negotiate it.
+func DefaultRegistry() *Registry {
+	return NewRegistry(JSON, MsgPack)
+}
+
+// Lookup returns the codec registered under name, if any.
+func (r *Registry) Lookup(name string) (Codec, bool) {
+	c, ok := r.codecs[name]
+	return c, ok
+}
+
+// Default returns the codec used when a client negotiates no known
+// protocol.
+func (r *Registry) Default() Codec {
+	return r.fallback
+}
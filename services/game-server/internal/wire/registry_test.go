@@ -0,0 +1,58 @@
+package wire
+
+import "testing"
+
+func TestDefaultRegistryLookup(t *testing.T) {
+	r := DefaultRegistry()
+
+	if r.Default() != JSON {
+		t.Fatalf("expected default codec to be JSON, got %v", r.Default())
+	}
+
+	c, ok := r.Lookup("arcadium.msgpack.v1")
+	if !ok || c != MsgPack {
+		t.Fatalf("expected to find MsgPack codec, got %v, %v", c, ok)
+	}
+
+	if _, ok := r.Lookup("arcadium.proto.v1"); ok {
+		t.Fatal("expected protobuf codec to not be registered yet")
+	}
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	data, err := JSON.Marshal(payload{Name: "arcadium"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var out payload
+	if err := JSON.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Name != "arcadium" {
+		t.Fatalf("expected round-tripped name arcadium, got %q", out.Name)
+	}
+}
+
+func TestMsgPackCodecRoundTrip(t *testing.T) {
+	type payload struct {
+		Name string `msgpack:"name"`
+	}
+
+	data, err := MsgPack.Marshal(payload{Name: "arcadium"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	var out payload
+	if err := MsgPack.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Name != "arcadium" {
+		t.Fatalf("expected round-tripped name arcadium, got %q", out.Name)
+	}
+}
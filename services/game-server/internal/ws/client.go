@@ -0,0 +1,136 @@
+// Package ws owns the WebSocket connection lifecycle: upgrading requests,
+// pumping messages to and from gorilla/websocket, and translating them to
+// and from the coordinator's typed commands. It has no game logic of its
+// own.
+package ws
+
+import (
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/coordinator"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	sendBuffer = 16
+)
+
+// outboundFrame is an already-encoded message waiting to be written to the
+// connection, tagged with whether it must be sent as a binary frame.
+type outboundFrame struct {
+	data   []byte
+	binary bool
+}
+
+// Conn adapts a gorilla/websocket connection to coordinator.Client, encoding
+// and decoding messages with its negotiated wire.Codec.
+type Conn struct {
+	id     string
+	userID string
+	codec  wire.Codec
+	hub    *coordinator.Hub
+	conn   *websocket.Conn
+	send   chan outboundFrame
+}
+
+// NewConn wraps conn for use with hub, identified by id and the userID
+// established by the authentication check performed before upgrade, using
+// codec to encode and decode frames.
+func NewConn(id, userID string, codec wire.Codec, hub *coordinator.Hub, conn *websocket.Conn) *Conn {
+	return &Conn{
+		id:     id,
+		userID: userID,
+		codec:  codec,
+		hub:    hub,
+		conn:   conn,
+		send:   make(chan outboundFrame, sendBuffer),
+	}
+}
+
+// ID implements coordinator.Client.
+func (c *Conn) ID() string { return c.id }
+
+// UserID returns the identity attached to this connection at upgrade time.
+func (c *Conn) UserID() string { return c.userID }
+
+// Codec implements coordinator.Client.
+func (c *Conn) Codec() wire.Codec { return c.codec }
+
+// Send implements coordinator.Client. It encodes resp with the connection's
+// codec and never blocks the caller; if the client's outbound queue is full
+// the response is dropped rather than stalling the hub's run loop.
+func (c *Conn) Send(resp coordinator.SessionResponse) {
+	data, err := c.codec.Marshal(resp)
+	if err != nil {
+		log.Printf("ws: client %s failed to encode %s response: %v", c.id, resp.Command, err)
+		return
+	}
+	c.enqueue(data, c.codec.Binary())
+}
+
+// SendRaw implements coordinator.Client. It delivers an already-encoded
+// frame, used by hub broadcasts that encode once per codec rather than once
+// per connection.
+func (c *Conn) SendRaw(data []byte, binary bool) {
+	c.enqueue(data, binary)
+}
+
+func (c *Conn) enqueue(data []byte, binary bool) {
+	select {
+	case c.send <- outboundFrame{data: data, binary: binary}:
+	default:
+		log.Printf("ws: client %s send buffer full, dropping frame", c.id)
+	}
+}
+
+// ReadPump reads frames off the connection, decodes them with the
+// connection's codec, and dispatches the resulting commands to the hub
+// until the connection errors or closes. It must run in its own goroutine
+// and owns closing conn.
+func (c *Conn) ReadPump() {
+	defer func() {
+		c.hub.Unregister(c)
+		close(c.send)
+		c.conn.Close()
+	}()
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			log.Printf("ws: client %s read error: %v", c.id, err)
+			return
+		}
+
+		var cmd coordinator.SessionCommand
+		if err := c.codec.Unmarshal(data, &cmd); err != nil {
+			log.Printf("ws: client %s failed to decode command: %v", c.id, err)
+			continue
+		}
+		c.hub.Dispatch(c, cmd)
+	}
+}
+
+// WritePump drains the client's outbound queue onto the connection until the
+// channel is closed or a write fails. It must run in its own goroutine. On a
+// write failure it closes conn so a blocked ReadPump (e.g. a peer that
+// stopped reading but never tore down the TCP connection) unblocks and runs
+// the disconnect/unregister path instead of leaking the client forever.
+func (c *Conn) WritePump() {
+	for frame := range c.send {
+		msgType := websocket.TextMessage
+		if frame.binary {
+			msgType = websocket.BinaryMessage
+		}
+
+		c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := c.conn.WriteMessage(msgType, frame.data); err != nil {
+			log.Printf("ws: client %s write error: %v", c.id, err)
+			c.conn.Close()
+			return
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/auth"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/coordinator"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+)
+
+// ReauthInterval is how often an established connection's token is
+// re-validated for expiry and revocation.
+const ReauthInterval = 30 * time.Second
+
+var nextConnID uint64
+
+// HubResolver picks which coordinator.Hub a given upgrade request should be
+// routed to, e.g. based on a `?lobby=` query parameter. It is called before
+// the connection is upgraded so a bad request can be rejected with a normal
+// HTTP error instead of a WebSocket close frame.
+type HubResolver func(r *http.Request) (*coordinator.Hub, error)
+
+// Config bundles everything Handler needs to validate and route an upgrade
+// request.
+type Config struct {
+	// Resolve picks the hub the connection should join.
+	Resolve HubResolver
+	// Origins restricts which Origin headers may upgrade. A nil value
+	// allows every origin.
+	Origins *OriginAllowList
+	// Auth validates the JWT presented by the connecting client.
+	Auth *auth.Issuer
+	// Wire resolves the negotiated Sec-WebSocket-Protocol codec. A nil
+	// value falls back to wire.DefaultRegistry().
+	Wire *wire.Registry
+}
+
+// Handler returns an http.HandlerFunc that validates the upgrade request
+// and its bearer token, resolves the target hub, upgrades the request to a
+// WebSocket, registers a new Conn with that hub, and runs its read/write
+// pumps alongside a background goroutine that tears the connection down if
+// the token expires or is revoked.
+func Handler(cfg Config) http.HandlerFunc {
+	registry := cfg.Wire
+	if registry == nil {
+		registry = wire.DefaultRegistry()
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			return cfg.Origins.Allowed(origin)
+		},
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := validateUpgradeRequest(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		codec, token, negotiated := negotiate(r, registry)
+		if token == "" {
+			http.Error(w, "missing authentication token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := cfg.Auth.Parse(token)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid authentication token: %v", err), http.StatusUnauthorized)
+			return
+		}
+
+		hub, err := cfg.Resolve(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Only echo Sec-WebSocket-Protocol back when the client actually
+		// offered a codec subprotocol. Responding with one the client never
+		// requested violates RFC 6455 and causes spec-compliant clients to
+		// abort the handshake, which would otherwise break every connection
+		// authenticating via the ?token= query parameter.
+		var responseHeader http.Header
+		if negotiated {
+			responseHeader = http.Header{"Sec-WebSocket-Protocol": {codec.Name()}}
+		}
+		rawConn, err := upgrader.Upgrade(w, r, responseHeader)
+		if err != nil {
+			log.Printf("ws: upgrade error: %v", err)
+			return
+		}
+
+		id := fmt.Sprintf("conn-%d", atomic.AddUint64(&nextConnID, 1))
+		conn := NewConn(id, claims.UserID, codec, hub, rawConn)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go reauthLoop(ctx, cfg.Auth, token, rawConn)
+
+		hub.Register(conn)
+		go conn.WritePump()
+		conn.ReadPump()
+	}
+}
+
+// reauthLoop periodically re-validates token and closes conn the moment it
+// expires or is revoked, rather than trusting it for the connection's
+// entire lifetime. It returns once ctx is canceled, which Handler does as
+// soon as the connection's ReadPump returns.
+func reauthLoop(ctx context.Context, issuer *auth.Issuer, token string, conn *websocket.Conn) {
+	ticker := time.NewTicker(ReauthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := issuer.Parse(token); err != nil {
+				log.Printf("ws: closing connection after re-auth failure: %v", err)
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// negotiate picks the wire codec and bearer token from the request's
+// Sec-WebSocket-Protocol list, which may carry both (e.g.
+// "arcadium.msgpack.v1, <jwt>") in either order. Any listed protocol that
+// registry recognizes as a codec name is treated as the format negotiation;
+// everything else is treated as the auth token, falling back to the
+// `token` query parameter if none is present. The returned bool reports
+// whether a codec was actually named in the client's Sec-WebSocket-Protocol
+// list, as opposed to codec falling back to registry.Default(); callers must
+// not echo a Sec-WebSocket-Protocol response header when it is false, since
+// the client never offered one.
+func negotiate(r *http.Request, registry *wire.Registry) (codec wire.Codec, token string, negotiated bool) {
+	codec = registry.Default()
+
+	for _, protocol := range websocket.Subprotocols(r) {
+		if c, ok := registry.Lookup(protocol); ok {
+			codec = c
+			negotiated = true
+			continue
+		}
+		if token == "" {
+			token = protocol
+		}
+	}
+
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	return codec, token, negotiated
+}
+
+// validateUpgradeRequest rejects requests that could never be a valid
+// WebSocket upgrade before handing them to gorilla/websocket, so malformed
+// clients get a clear 400 instead of an opaque library error.
+func validateUpgradeRequest(r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return fmt.Errorf("websocket upgrade requires GET, got %s", r.Method)
+	}
+	if !headerTokenPresent(r.Header, "Connection", "upgrade") {
+		return fmt.Errorf("missing required %q header", "Connection: Upgrade")
+	}
+	if !headerTokenPresent(r.Header, "Upgrade", "websocket") {
+		return fmt.Errorf("missing required %q header", "Upgrade: websocket")
+	}
+	return nil
+}
+
+// headerTokenPresent reports whether any comma-separated token in the named
+// header equals want, case-insensitively (per RFC 7230's list syntax, used
+// by both the Connection and Upgrade headers).
+func headerTokenPresent(h http.Header, name, want string) bool {
+	for _, token := range strings.Split(h.Get(name), ",") {
+		if strings.EqualFold(strings.TrimSpace(token), want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/auth"
+	"github.com/gabe-kai/arcadium/services/game-server/internal/coordinator"
+)
+
+func resolverStub(t *testing.T) HubResolver {
+	return func(r *http.Request) (*coordinator.Hub, error) {
+		t.Fatal("resolve should not be reached for a request that fails upgrade validation")
+		return nil, nil
+	}
+}
+
+func testIssuer(t *testing.T) *auth.Issuer {
+	t.Helper()
+	return auth.NewIssuer([]byte("test-secret"), time.Minute)
+}
+
+func TestHandlerRejectsNonGET(t *testing.T) {
+	h := Handler(Config{Resolve: resolverStub(t), Auth: testIssuer(t)})
+
+	req := httptest.NewRequest(http.MethodPost, "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for non-GET request, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsMissingUpgradeHeaders(t *testing.T) {
+	h := Handler(Config{Resolve: resolverStub(t), Auth: testIssuer(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing Connection/Upgrade headers, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsMissingConnectionHeader(t *testing.T) {
+	h := Handler(Config{Resolve: resolverStub(t), Auth: testIssuer(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Upgrade", "websocket")
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing Connection header, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsMissingToken(t *testing.T) {
+	h := Handler(Config{Resolve: resolverStub(t), Auth: testIssuer(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for missing token, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsInvalidToken(t *testing.T) {
+	h := Handler(Config{Resolve: resolverStub(t), Auth: testIssuer(t)})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws?token=not-a-real-token", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for invalid token, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsDisallowedOrigin(t *testing.T) {
+	hub := coordinator.NewHub()
+	go hub.Run()
+
+	issuer := testIssuer(t)
+	token, _, err := issuer.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	resolve := func(r *http.Request) (*coordinator.Hub, error) { return hub, nil }
+	h := Handler(Config{
+		Resolve: resolve,
+		Origins: ParseOriginAllowList("https://allowed.example.com"),
+		Auth:    issuer,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws?token="+token, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	rec := httptest.NewRecorder()
+
+	h(rec, req)
+
+	// gorilla/websocket's CheckOrigin rejection surfaces as 403, distinct
+	// from the 400/401s this package raises for malformed or unauthenticated
+	// requests.
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for disallowed origin, got %d", rec.Code)
+	}
+}
+
+// TestHandlerOmitsSubprotocolWhenClientOffersNone drives a real handshake
+// (rather than an httptest.NewRecorder status check) over the documented
+// ?token= query-param auth path, which sends no Sec-WebSocket-Protocol
+// header at all. gorilla/websocket's client-side Dialer rejects the
+// handshake outright if the server echoes a subprotocol it never offered,
+// so a successful Dial here is itself the assertion.
+func TestHandlerOmitsSubprotocolWhenClientOffersNone(t *testing.T) {
+	hub := coordinator.NewHub()
+	go hub.Run()
+
+	issuer := testIssuer(t)
+	token, _, err := issuer.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	resolve := func(r *http.Request) (*coordinator.Hub, error) { return hub, nil }
+	srv := httptest.NewServer(Handler(Config{Resolve: resolve, Auth: issuer}))
+	defer srv.Close()
+
+	url := "ws" + srv.URL[len("http"):] + "/ws?token=" + token
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected handshake to succeed, got error: %v", err)
+	}
+	defer conn.Close()
+
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != "" {
+		t.Fatalf("expected no Sec-WebSocket-Protocol response header, got %q", got)
+	}
+}
+
+// TestHandlerEchoesSubprotocolWhenClientOffersOne covers the other side of
+// the same handshake: when the client does offer a codec subprotocol, the
+// server must still echo it back so negotiation actually works.
+func TestHandlerEchoesSubprotocolWhenClientOffersOne(t *testing.T) {
+	hub := coordinator.NewHub()
+	go hub.Run()
+
+	issuer := testIssuer(t)
+	token, _, err := issuer.Mint("player-1")
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+
+	resolve := func(r *http.Request) (*coordinator.Hub, error) { return hub, nil }
+	srv := httptest.NewServer(Handler(Config{Resolve: resolve, Auth: issuer}))
+	defer srv.Close()
+
+	url := "ws" + srv.URL[len("http"):] + "/ws"
+	dialer := *websocket.DefaultDialer
+	dialer.Subprotocols = []string{"arcadium.msgpack.v1", token}
+	conn, resp, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("expected handshake to succeed, got error: %v", err)
+	}
+	defer conn.Close()
+
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != "arcadium.msgpack.v1" {
+		t.Fatalf("expected echoed Sec-WebSocket-Protocol arcadium.msgpack.v1, got %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabe-kai/arcadium/services/game-server/internal/wire"
+)
+
+func TestNegotiateCodecAndToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Sec-WebSocket-Protocol", "arcadium.msgpack.v1, some.jwt.token")
+
+	codec, token, negotiated := negotiate(req, wire.DefaultRegistry())
+
+	if codec != wire.MsgPack {
+		t.Fatalf("expected MsgPack codec, got %v", codec)
+	}
+	if token != "some.jwt.token" {
+		t.Fatalf("expected token some.jwt.token, got %q", token)
+	}
+	if !negotiated {
+		t.Fatal("expected negotiated to be true when the client offered a codec subprotocol")
+	}
+}
+
+func TestNegotiateDefaultsToJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws?token=some.jwt.token", nil)
+
+	codec, token, negotiated := negotiate(req, wire.DefaultRegistry())
+
+	if codec != wire.JSON {
+		t.Fatalf("expected JSON codec by default, got %v", codec)
+	}
+	if token != "some.jwt.token" {
+		t.Fatalf("expected token from query param, got %q", token)
+	}
+	if negotiated {
+		t.Fatal("expected negotiated to be false when the client sent no Sec-WebSocket-Protocol header")
+	}
+}
+
+func TestNegotiateTokenOrderIndependent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Sec-WebSocket-Protocol", "some.jwt.token, arcadium.msgpack.v1")
+
+	codec, token, negotiated := negotiate(req, wire.DefaultRegistry())
+
+	if codec != wire.MsgPack {
+		t.Fatalf("expected MsgPack codec, got %v", codec)
+	}
+	if token != "some.jwt.token" {
+		t.Fatalf("expected token some.jwt.token, got %q", token)
+	}
+	if !negotiated {
+		t.Fatal("expected negotiated to be true when the client offered a codec subprotocol")
+	}
+}
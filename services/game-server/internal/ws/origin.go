@@ -0,0 +1,61 @@
+package ws
+
+import "strings"
+
+// OriginAllowList decides whether a WebSocket upgrade's Origin header is
+// permitted. It is configured from a comma-separated list of patterns; a
+// pattern of "*" matches any origin, and a pattern beginning with "*." is
+// treated as a suffix match against the whole Origin value (e.g.
+// "*.example.com" matches "https://app.example.com" but not
+// "https://example.com"). The port is not stripped first, so
+// "https://app.example.com:8443" does not match "*.example.com".
+type OriginAllowList struct {
+	allowAll bool
+	exact    map[string]bool
+	suffixes []string
+}
+
+// ParseOriginAllowList builds an OriginAllowList from a comma-separated env
+// var value. An empty list allows every origin, matching the permissive
+// default this server shipped with before origin checking existed.
+func ParseOriginAllowList(csv string) *OriginAllowList {
+	o := &OriginAllowList{exact: make(map[string]bool)}
+
+	for _, raw := range strings.Split(csv, ",") {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" {
+			continue
+		}
+		switch {
+		case pattern == "*":
+			o.allowAll = true
+		case strings.HasPrefix(pattern, "*."):
+			o.suffixes = append(o.suffixes, pattern[1:]) // keep the leading dot
+		default:
+			o.exact[pattern] = true
+		}
+	}
+
+	if len(o.exact) == 0 && len(o.suffixes) == 0 && !o.allowAll {
+		o.allowAll = true
+	}
+
+	return o
+}
+
+// Allowed reports whether origin is permitted to open a WebSocket
+// connection.
+func (o *OriginAllowList) Allowed(origin string) bool {
+	if o == nil || o.allowAll {
+		return true
+	}
+	if o.exact[origin] {
+		return true
+	}
+	for _, suffix := range o.suffixes {
+		if strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package ws
+
+import "testing"
+
+func TestOriginAllowListEmptyAllowsAll(t *testing.T) {
+	o := ParseOriginAllowList("")
+	if !o.Allowed("https://anything.example") {
+		t.Fatal("expected empty allow-list to allow all origins")
+	}
+}
+
+func TestOriginAllowListExactMatch(t *testing.T) {
+	o := ParseOriginAllowList("https://play.example.com, https://staging.example.com")
+
+	if !o.Allowed("https://play.example.com") {
+		t.Fatal("expected exact match to be allowed")
+	}
+	if o.Allowed("https://evil.example.com") {
+		t.Fatal("expected non-listed origin to be denied")
+	}
+}
+
+func TestOriginAllowListWildcardSuffix(t *testing.T) {
+	o := ParseOriginAllowList("*.example.com")
+
+	if !o.Allowed("https://app.example.com") {
+		t.Fatal("expected subdomain to match wildcard suffix")
+	}
+	if o.Allowed("https://example.com") {
+		t.Fatal("expected bare domain without subdomain to not match *.example.com")
+	}
+	if o.Allowed("https://notexample.com") {
+		t.Fatal("expected lookalike domain without the dot separator to be denied")
+	}
+}
+
+func TestOriginAllowListWildcardStar(t *testing.T) {
+	o := ParseOriginAllowList("*")
+	if !o.Allowed("https://anything.example") {
+		t.Fatal("expected * to allow all origins")
+	}
+}